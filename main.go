@@ -9,10 +9,11 @@
 package tears
 
 import (
-	"cmp"
 	"context"
+	"errors"
 	"fmt"
-	"slices"
+	"reflect"
+	"sync"
 	"time"
 )
 
@@ -20,7 +21,7 @@ import (
 var Timeout = 15 * time.Second
 
 // TearFn is a function that allows to add a cleanup function.
-type TearFn func(c any) Tear
+type TearFn func(c any, args ...any) *Tear
 
 // DownFn is a function that runs the cleanup functions in reverse order.
 type DownFn func(context.Context) error
@@ -32,19 +33,69 @@ func New() (TearFn, DownFn) {
 	return cleaner.Tear, cleaner.Down
 }
 
+// NewWithDebug behaves like New, but the Debug field of the Cleaner behind
+// the returned functions is set to debug, so every cleanup function Down
+// runs is logged.
+func NewWithDebug(debug func(msg string)) (TearFn, DownFn) {
+	var cleaner Cleaner
+	cleaner.Debug = debug
+	return cleaner.Tear, cleaner.Down
+}
+
 // Cleaner allows to register cleanup functions and run them in reverse order.
-// it is not safe for concurrent use. A Cleaner can be embbeded into another
-// struct to provide tear-down functionality.
-type Cleaner []Tear
+// It is safe for concurrent use: Tear/TearAsync and Down may be called from
+// multiple goroutines. A Cleaner can be embbeded into another struct to
+// provide tear-down functionality.
+type Cleaner struct {
+	// Debug, if set, receives a log line for every cleanup function Down
+	// starts and finishes. This can be used to log to a logger:
+	//  var cl Cleaner
+	//  cl.Debug = func(msg string) { log.Print(msg) }
+	Debug func(msg string)
+
+	mu    sync.Mutex
+	tears []*Tear
+}
+
+func (c *Cleaner) debugf(format string, v ...any) {
+	if c.Debug != nil {
+		c.Debug(fmt.Sprintf(format, v...))
+	}
+}
 
 type Tear struct {
 	fn func(context.Context) error
 
+	// name identifies the registered cleanup function for error messages and
+	// debug logging, resolved once at registration time.
+	name string
+
 	// Usually the cleanup functions are run in the reverse order they have been
 	// added, and in a FIFO manner. The additonal priority allows to break out of
 	// this. By setting a low (maybe even negative) priority the cleanup function
 	// will run later. By setting a high priority it will run earlier.
 	prio int
+
+	// timeout overrides the package Timeout for this Tear, if non-zero. See
+	// WithTimeout.
+	timeout time.Duration
+
+	// async marks a Tear as having no order dependency on other Tears of the
+	// same priority. Contiguous async Tears sharing a priority are run
+	// concurrently by Down, see TearAsync.
+	async bool
+
+	// done is set once Down has started running this Tear, so that Tears
+	// still pending, including ones registered while Down is already in
+	// progress, can be told apart from ones Down has already taken.
+	done bool
+
+	// released is set by Release/ReleaseAll to cancel a Tear before it runs.
+	released bool
+
+	// owner is the Cleaner this Tear was registered with, used by Release to
+	// synchronize with a concurrently running Down.
+	owner *Cleaner
 }
 
 // End will cause the cleanup function to be run at the end of the cleanup
@@ -55,68 +106,337 @@ func (t *Tear) End() Tear {
 	return *t
 }
 
+// WithTimeout overrides the package-wide Timeout for this Tear, so that
+// individual cleanups with known-slow or known-fast shutdown behavior can
+// set their own deadline.
+func (t *Tear) WithTimeout(d time.Duration) Tear {
+	t.timeout = d
+	return *t
+}
+
+// Release cancels this Tear: it will not run on Down, unless Down has
+// already started running it. This follows the pattern of gVisor's
+// cleanup.Cleanup: register a cleanup immediately after acquiring a
+// resource, then Release it once ownership has been transferred elsewhere,
+// so that an early return due to an error still cleans up, but the happy
+// path does not double-close the resource. Release is safe to call
+// concurrently with Down.
+func (t *Tear) Release() {
+	if t.owner == nil {
+		return
+	}
+	t.owner.mu.Lock()
+	t.released = true
+	t.owner.mu.Unlock()
+}
+
+// ReleaseAll cancels every Tear registered with c that has not yet run. This
+// is useful for a Cleaner returned from a scoped helper: once the caller
+// decides none of its registered cleanups are needed anymore, ReleaseAll
+// cancels them all at once.
+func (c *Cleaner) ReleaseAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, t := range c.tears {
+		t.released = true
+	}
+}
+
 // Tear accepts a wide range of types that can be used as cleanup functions and
-// types. Tear will schedule the cleanup function to be run on Down.
-func (c *Cleaner) Tear(v any) Tear {
-	var t Tear
-
-	switch v.(type) {
-	case func(): //  no context, no error, also covers context.CancelFunc
-		t.fn = func(context.Context) error {
-			v.(func())()
-			return nil
-		}
-	case func() error: // no context, with error
-		t.fn = func(context.Context) error {
-			return v.(func() error)()
+// types. Tear will schedule the cleanup function to be run on Down, in order,
+// with a strict dependency on the Tears around it. Use TearAsync when there
+// is no such dependency.
+//
+// If args are given, v must be a function and the args are bound to its
+// trailing parameters, e.g. c.Tear(os.Remove, tmpPath) or
+// c.Tear(db.CloseConn, conn, ctx). See bind for the supported signatures.
+func (c *Cleaner) Tear(v any, args ...any) *Tear {
+	return c.add(v, args, false)
+}
+
+// TearAsync behaves like Tear, except the registered cleanup function is
+// marked as having no order dependency on other Tears of the same priority.
+// On Down, async Tears sharing a priority are launched concurrently and
+// joined before Down moves on; a non-async Tear of the same priority added
+// in between splits them into separate batches, see Down. Use this when tearing
+// down independent resources, e.g. several HTTP servers, that can be closed
+// at the same time.
+func (c *Cleaner) TearAsync(v any, args ...any) *Tear {
+	return c.add(v, args, true)
+}
+
+func (c *Cleaner) add(v any, args []any, async bool) *Tear {
+	t := &Tear{async: async, owner: c, name: nameOf(v)}
+
+	if len(args) > 0 {
+		t.fn = bind(v, args)
+	} else {
+		switch v.(type) {
+		case func(): //  no context, no error, also covers context.CancelFunc
+			t.fn = func(context.Context) error {
+				v.(func())()
+				return nil
+			}
+		case func() error: // no context, with error
+			t.fn = func(context.Context) error {
+				return v.(func() error)()
+			}
+		case func(context.Context): // with context, no error
+			t.fn = func(ctx context.Context) error {
+				v.(func(context.Context))(ctx)
+				return nil
+			}
+		case func(context.Context) error: // with context, with error
+			t.fn = v.(func(context.Context) error)
+		case chan<- bool: // quit-channel
+			t.fn = func(context.Context) error {
+				v.(chan<- bool) <- true
+				return nil
+			}
+		default:
+			panic(fmt.Sprintf("unsupported type %T", v))
 		}
-	case func(context.Context): // with context, no error
-		t.fn = func(ctx context.Context) error {
-			v.(func(context.Context))(ctx)
-			return nil
+	}
+
+	c.mu.Lock()
+	c.tears = append(c.tears, t)
+	c.mu.Unlock()
+	return t
+}
+
+var (
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+)
+
+// nameOf resolves a human-readable name for a registered cleanup value, used
+// in error messages and debug logging. Non-func values, e.g. a quit-channel,
+// fall back to their type name since funcName only makes sense for funcs.
+func nameOf(v any) string {
+	if reflect.ValueOf(v).Kind() != reflect.Func {
+		return fmt.Sprintf("%T", v)
+	}
+	return funcName(v)
+}
+
+// bind validates that args are assignable to the trailing parameters of fn
+// (after an optional leading context.Context parameter) and returns a
+// closure invoking fn with them via reflect. It panics with a descriptive
+// message on arity or type mismatch, so that such mistakes surface at
+// registration time rather than on Down.
+//
+// Like the types Tear accepts without args, fn may take a leading
+// context.Context, which Down supplies. fn may return any values: if the
+// last one is of type error and non-nil, it is returned; all other return
+// values are ignored.
+func bind(fn any, args []any) func(context.Context) error {
+	fnVal := reflect.ValueOf(fn)
+	fnType := fnVal.Type()
+	if fnType.Kind() != reflect.Func {
+		panic(fmt.Sprintf("tears: Tear requires a function to bind args to, got %T", fn))
+	}
+
+	wantsCtx := fnType.NumIn() > 0 && fnType.In(0) == contextType
+	fixed := fnType.NumIn()
+	if wantsCtx {
+		fixed--
+	}
+	if fixed != len(args) {
+		panic(fmt.Sprintf("tears: %s expects %d bound argument(s), got %d", funcName(fn), fixed, len(args)))
+	}
+
+	bound := make([]reflect.Value, len(args))
+	for i, a := range args {
+		want := fnType.In(i + offset(wantsCtx))
+		var got reflect.Value
+		if a == nil {
+			switch want.Kind() {
+			case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+				got = reflect.Zero(want)
+			default:
+				panic(fmt.Sprintf("tears: %s argument %d: cannot assign nil to %s", funcName(fn), i, want))
+			}
+		} else {
+			got = reflect.ValueOf(a)
+			if !got.Type().AssignableTo(want) {
+				panic(fmt.Sprintf("tears: %s argument %d: cannot assign %s to %s", funcName(fn), i, got.Type(), want))
+			}
 		}
-	case func(context.Context) error: // with context, with error
-		t.fn = v.(func(context.Context) error)
-	case chan<- bool: // quit-channel
-		t.fn = func(context.Context) error {
-			v.(chan<- bool) <- true
-			return nil
+		bound[i] = got
+	}
+
+	return func(ctx context.Context) error {
+		in := make([]reflect.Value, fnType.NumIn())
+		if wantsCtx {
+			in[0] = reflect.ValueOf(ctx)
 		}
-	default:
-		panic(fmt.Sprintf("unsupported type %T", v))
+		copy(in[offset(wantsCtx):], bound)
+		return lastError(fnVal.Call(in))
 	}
-	return t
 }
 
-// Down runs the cleanup functions in reverse order they have been added.
+// offset returns 1 if the bound function takes a leading context.Context.
+func offset(wantsCtx bool) int {
+	if wantsCtx {
+		return 1
+	}
+	return 0
+}
+
+// lastError returns the last return value of a call if it is a non-nil
+// error, ignoring any other returned values.
+func lastError(out []reflect.Value) error {
+	if len(out) == 0 {
+		return nil
+	}
+	last := out[len(out)-1]
+	if last.Type() != errorType || last.IsNil() {
+		return nil
+	}
+	return last.Interface().(error)
+}
+
+// Down runs the cleanup functions, highest priority first. Cleanup functions
+// run in reverse order they have been added, unless their priority says
+// otherwise. A cleanup function is free to call Tear or TearAsync again, e.g.
+// to register further cleanups on the same Cleaner; any Tear added this way
+// is drained, in reverse-append order, before Down moves on to the next
+// pending Tear. This mirrors the semantics of Go's testing.T.Cleanup.
+//
+// Tears added via TearAsync that share a priority with other pending async
+// Tears are run concurrently and joined before Down continues, but only for
+// the contiguous run of async Tears immediately preceding the one Down is
+// about to run: a Tear of the same priority sitting in between still acts
+// as a barrier, so its order relative to its neighbors is preserved. Each
+// Tear is bounded by Timeout, or its own WithTimeout override, and
+// ctx.Done() acts as a hard cap on the whole invocation: once ctx is done,
+// Down stops starting further Tears and returns.
+//
+// All errors encountered, including timeouts, are collected and returned
+// joined with errors.Join, each annotated with the name of the cleanup
+// function that produced it, so callers can errors.Is/errors.As against an
+// individual failure.
 func (c *Cleaner) Down(ctx context.Context) error {
-	errs := make(chan error, len(*c))
-
-	slices.SortFunc(*c, func(i, j Tear) int {
-		return cmp.Compare(i.prio, j.prio)
-	})
-	for i := len(*c) - 1; i >= 0; i-- {
-		// Run the cleanup function in a goroutine to prevent a deadlock in case
-		// a cleanup function is stalled/blocking.
-		done := make(chan bool)
-
-		go func() {
-			if err := (*c)[i].fn(ctx); err != nil {
-				errs <- err
-			}
-			done <- true
-		}()
+	var errs []error
 
-		select {
-		case <-done:
+	for {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, fmt.Errorf("down: %w", err))
 			break
-		case <-time.After(Timeout):
-			errs <- fmt.Errorf("timeout")
+		}
+
+		c.mu.Lock()
+		idx := nextPendingIndex(c.tears)
+		if idx == -1 {
+			c.mu.Unlock()
 			break
 		}
+		next := c.tears[idx]
+		if !next.async {
+			next.done = true
+			c.mu.Unlock()
+
+			if err := runTear(ctx, next); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		var batch []*Tear
+		for i := idx; i >= 0; i-- {
+			t := c.tears[i]
+			if t.done || t.released {
+				continue
+			}
+			if t.prio != next.prio {
+				continue
+			}
+			if !t.async {
+				break
+			}
+			t.done = true
+			batch = append(batch, t)
+		}
+		c.mu.Unlock()
+
+		errs = append(errs, runTearsAsync(ctx, batch)...)
 	}
-	if len(errs) > 0 {
-		return fmt.Errorf("%d errors encountered, first error: %s", len(errs), <-errs)
+	return errors.Join(errs...)
+}
+
+// nextPendingIndex returns the index of the highest-priority Tear that has
+// not yet run, or -1 if none is pending. Ties are broken in favor of the
+// most recently added Tear, so that Tears registered from within a running
+// cleanup are processed before older ones of the same priority.
+func nextPendingIndex(tears []*Tear) int {
+	next := -1
+	for i := len(tears) - 1; i >= 0; i-- {
+		t := tears[i]
+		if t.done || t.released {
+			continue
+		}
+		if next == -1 || t.prio > tears[next].prio {
+			next = i
+		}
+	}
+	return next
+}
+
+// runTear runs a single Tear's cleanup function, bounding it by its own
+// WithTimeout override or, absent that, the package Timeout. Errors,
+// including timeouts, are annotated with the cleanup function's name. Start
+// and finish are logged via the owning Cleaner's Debug, if set.
+func runTear(ctx context.Context, t *Tear) error {
+	timeout := Timeout
+	if t.timeout > 0 {
+		timeout = t.timeout
+	}
+
+	t.owner.debugf("Running %s (priority %d)...", t.name, t.prio)
+	start := time.Now()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- t.fn(ctx)
+	}()
+
+	var err error
+	select {
+	case e := <-done:
+		if e != nil {
+			err = fmt.Errorf("%s: %w", t.name, e)
+		}
+	case <-ctx.Done():
+		err = fmt.Errorf("%s: %w", t.name, ctx.Err())
+	case <-time.After(timeout):
+		err = fmt.Errorf("%s: timed out after %s", t.name, timeout)
+	}
+
+	t.owner.debugf("Ran %s (priority %d) in %s, error: %v", t.name, t.prio, time.Since(start), err)
+	return err
+}
+
+// runTearsAsync runs a batch of async Tears concurrently, joining on them via
+// a WaitGroup before returning. Each Tear is still individually bounded by
+// Timeout, or its own WithTimeout override, so the whole batch completes
+// within the longest of these.
+func runTearsAsync(ctx context.Context, batch []*Tear) []error {
+	var (
+		mu   sync.Mutex
+		errs []error
+		wg   sync.WaitGroup
+	)
+	for _, t := range batch {
+		wg.Add(1)
+		go func(t *Tear) {
+			defer wg.Done()
+			if err := runTear(ctx, t); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(t)
 	}
-	return nil
+	wg.Wait()
+	return errs
 }
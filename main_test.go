@@ -7,7 +7,13 @@ package tears
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestAdddedGetsCalledWithStructEmbed(t *testing.T) {
@@ -73,10 +79,441 @@ func TestDownOrderEnd(t *testing.T) {
 		return nil
 	}).End()
 
-	t.Logf("Tears: %#v", cl)
+	t.Logf("Tears: %#v", cl.tears)
 	cl.Down(context.Background())
 
 	if called[0] != 1 || called[1] != 2 {
 		t.Errorf("Expected cleanup to be called in order 1->2, got %v", called)
 	}
 }
+
+func TestDownNested(t *testing.T) {
+	var cl Cleaner
+
+	var called []int
+	cl.Tear(func() error {
+		called = append(called, 1)
+		cl.Tear(func() error {
+			called = append(called, 2)
+			return nil
+		})
+		return nil
+	})
+
+	cl.Down(context.Background())
+
+	if len(called) != 2 || called[0] != 1 || called[1] != 2 {
+		t.Errorf("Expected cleanup to be called in order 1->2, got %v", called)
+	}
+}
+
+func TestDownNestedFromEnd(t *testing.T) {
+	var cl Cleaner
+
+	var called []string
+	cl.Tear(func() error {
+		called = append(called, "normal")
+		return nil
+	})
+	cl.Tear(func() error {
+		called = append(called, "end")
+		cl.Tear(func() error {
+			called = append(called, "nested")
+			return nil
+		})
+		return nil
+	}).End()
+
+	cl.Down(context.Background())
+
+	want := []string{"normal", "end", "nested"}
+	if len(called) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, called)
+	}
+	for i := range want {
+		if called[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, called)
+		}
+	}
+}
+
+func TestDownNestedMixedPriority(t *testing.T) {
+	var cl Cleaner
+
+	var called []string
+	cl.Tear(func() error {
+		called = append(called, "first")
+		return nil
+	})
+	cl.Tear(func() error {
+		called = append(called, "second")
+		cl.Tear(func() error {
+			called = append(called, "second-nested-a")
+			return nil
+		})
+		cl.Tear(func() error {
+			called = append(called, "second-nested-b")
+			return nil
+		})
+		return nil
+	})
+	cl.Tear(func() error {
+		called = append(called, "end")
+		return nil
+	}).End()
+
+	cl.Down(context.Background())
+
+	want := []string{"second", "second-nested-b", "second-nested-a", "first", "end"}
+	if len(called) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, called)
+	}
+	for i := range want {
+		if called[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, called)
+		}
+	}
+}
+
+func TestTearAsyncRunsConcurrently(t *testing.T) {
+	var cl Cleaner
+
+	const n = 5
+	var running int32
+	var maxRunning int32
+	var wg sync.WaitGroup
+	wg.Add(n)
+
+	for i := 0; i < n; i++ {
+		cl.TearAsync(func() error {
+			defer wg.Done()
+			cur := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if cur <= max || atomic.CompareAndSwapInt32(&maxRunning, max, cur) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return nil
+		})
+	}
+
+	cl.Down(context.Background())
+	wg.Wait()
+
+	if maxRunning < 2 {
+		t.Errorf("Expected TearAsync cleanups to overlap, max concurrent was %d", maxRunning)
+	}
+}
+
+func TestTearAsyncJoinsBeforeNextBand(t *testing.T) {
+	var cl Cleaner
+
+	var called []string
+	var mu sync.Mutex
+	record := func(s string) {
+		mu.Lock()
+		called = append(called, s)
+		mu.Unlock()
+	}
+
+	cl.TearAsync(func() error {
+		time.Sleep(20 * time.Millisecond)
+		record("async")
+		return nil
+	})
+	cl.Tear(func() error {
+		record("end")
+		return nil
+	}).End()
+
+	cl.Down(context.Background())
+
+	if len(called) != 2 || called[0] != "async" || called[1] != "end" {
+		t.Errorf("Expected async to finish before end, got %v", called)
+	}
+}
+
+func TestDownMixedSyncAsyncSamePriority(t *testing.T) {
+	var cl Cleaner
+
+	var called []string
+	var mu sync.Mutex
+	record := func(s string) {
+		mu.Lock()
+		called = append(called, s)
+		mu.Unlock()
+	}
+
+	cl.Tear(func() error { record("A"); return nil })
+	cl.TearAsync(func() error { record("B"); return nil })
+	cl.Tear(func() error { record("C"); return nil })
+	cl.TearAsync(func() error { record("D"); return nil })
+
+	cl.Down(context.Background())
+
+	want := []string{"D", "C", "B", "A"}
+	if len(called) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, called)
+	}
+	for i := range want {
+		if called[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, called)
+		}
+	}
+}
+
+func TestCleanerSafeForConcurrentUse(t *testing.T) {
+	var cl Cleaner
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cl.Tear(func() error { return nil })
+		}()
+	}
+	wg.Wait()
+
+	if err := cl.Down(context.Background()); err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+}
+
+func TestTearRelease(t *testing.T) {
+	var cl Cleaner
+
+	var called bool
+	tr := cl.Tear(func() error {
+		called = true
+		return nil
+	})
+	tr.Release()
+
+	cl.Down(context.Background())
+
+	if called {
+		t.Error("Expected released cleanup not to be called")
+	}
+}
+
+func TestTearReleaseLeavesOthers(t *testing.T) {
+	var cl Cleaner
+
+	var called []int
+	cl.Tear(func() error {
+		called = append(called, 1)
+		return nil
+	})
+	tr := cl.Tear(func() error {
+		called = append(called, 2)
+		return nil
+	})
+	tr.Release()
+
+	cl.Down(context.Background())
+
+	if len(called) != 1 || called[0] != 1 {
+		t.Errorf("Expected only the un-released cleanup to run, got %v", called)
+	}
+}
+
+func TestCleanerReleaseAll(t *testing.T) {
+	var cl Cleaner
+
+	var called bool
+	cl.Tear(func() error {
+		called = true
+		return nil
+	})
+	cl.Tear(func() error {
+		called = true
+		return nil
+	})
+	cl.ReleaseAll()
+
+	if err := cl.Down(context.Background()); err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+	if called {
+		t.Error("Expected no cleanup to be called after ReleaseAll")
+	}
+}
+
+func TestTearBoundArgs(t *testing.T) {
+	var cl Cleaner
+
+	var got string
+	remove := func(path string) error {
+		got = path
+		return nil
+	}
+	cl.Tear(remove, "/tmp/example")
+
+	if err := cl.Down(context.Background()); err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+	if got != "/tmp/example" {
+		t.Errorf("Expected bound arg to be passed through, got %q", got)
+	}
+}
+
+func TestTearBoundArgsWithContext(t *testing.T) {
+	var cl Cleaner
+
+	type conn struct{ id int }
+	var gotCtx context.Context
+	var gotConn conn
+	closeConn := func(ctx context.Context, c conn) error {
+		gotCtx = ctx
+		gotConn = c
+		return nil
+	}
+	cl.Tear(closeConn, conn{id: 7})
+
+	ctx := context.WithValue(context.Background(), "k", "v")
+	if err := cl.Down(ctx); err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+	if gotCtx != ctx {
+		t.Error("Expected Down's context to be passed through")
+	}
+	if gotConn.id != 7 {
+		t.Errorf("Expected bound conn to be passed through, got %+v", gotConn)
+	}
+}
+
+func TestTearBoundArgsMultiReturnLastError(t *testing.T) {
+	var cl Cleaner
+
+	fn := func(n int) (int, error) {
+		return n * 2, fmt.Errorf("boom")
+	}
+	cl.Tear(fn, 21)
+
+	err := cl.Down(context.Background())
+	if err == nil {
+		t.Fatal("Expected the trailing error to fail Down")
+	}
+}
+
+func TestTearBoundArgsArityMismatchPanics(t *testing.T) {
+	var cl Cleaner
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Tear to panic on arity mismatch")
+		}
+	}()
+	cl.Tear(func(a, b string) error { return nil }, "only-one")
+}
+
+func TestTearBoundArgsTypeMismatchPanics(t *testing.T) {
+	var cl Cleaner
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Tear to panic on type mismatch")
+		}
+	}()
+	cl.Tear(func(n int) error { return nil }, "not-an-int")
+}
+
+func TestDownAggregatesAllErrors(t *testing.T) {
+	var cl Cleaner
+
+	err1 := fmt.Errorf("first failure")
+	err2 := fmt.Errorf("second failure")
+	cl.Tear(func() error { return err1 })
+	cl.Tear(func() error { return err2 })
+
+	err := cl.Down(context.Background())
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if !errors.Is(err, err1) {
+		t.Errorf("Expected %v to be found in %v", err1, err)
+	}
+	if !errors.Is(err, err2) {
+		t.Errorf("Expected %v to be found in %v", err2, err)
+	}
+}
+
+func TestTearWithTimeout(t *testing.T) {
+	orig := Timeout
+	Timeout = time.Second
+	defer func() { Timeout = orig }()
+
+	var cl Cleaner
+	cl.Tear(func() error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}).WithTimeout(10 * time.Millisecond)
+
+	err := cl.Down(context.Background())
+	if err == nil {
+		t.Fatal("Expected the short per-Tear timeout to fire")
+	}
+}
+
+func TestDownStopsOnContextDone(t *testing.T) {
+	var cl Cleaner
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var called bool
+	cl.Tear(func() error {
+		called = true
+		return nil
+	})
+
+	err := cl.Down(ctx)
+	if err == nil {
+		t.Fatal("Expected an error reporting the context was already done")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the error to wrap context.Canceled, got %v", err)
+	}
+	if called {
+		t.Error("Expected no Tear to run once ctx was already done")
+	}
+}
+
+func TestCleanerDebug(t *testing.T) {
+	var cl Cleaner
+
+	var msgs []string
+	cl.Debug = func(msg string) {
+		msgs = append(msgs, msg)
+	}
+
+	cl.Tear(func() error { return nil })
+	cl.Down(context.Background())
+
+	if len(msgs) != 2 {
+		t.Fatalf("Expected 2 debug messages, got %d: %v", len(msgs), msgs)
+	}
+	for _, msg := range msgs {
+		if !strings.Contains(msg, "TestCleanerDebug") {
+			t.Errorf("Expected debug message to name the cleanup func, got %q", msg)
+		}
+	}
+}
+
+func TestNewWithDebug(t *testing.T) {
+	var msgs []string
+	tear, down := NewWithDebug(func(msg string) {
+		msgs = append(msgs, msg)
+	})
+
+	tear(func() error { return nil })
+	down(context.Background())
+
+	if len(msgs) != 2 {
+		t.Errorf("Expected 2 debug messages, got %d: %v", len(msgs), msgs)
+	}
+}
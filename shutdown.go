@@ -0,0 +1,75 @@
+// Copyright 2024 Marius Wilms All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tears
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// RunUntilSignal creates a Cleaner, lets register add cleanups to it via
+// the given TearFn, and then, in the background, waits for any of sigs
+// (defaulting to os.Interrupt and syscall.SIGTERM) or ctx.Done(), whichever
+// comes first. Once either fires, it runs Down on a fresh shutdown context
+// bounded by Timeout and reports the result on the returned channel, which
+// is closed afterwards.
+//
+// register runs synchronously, before the background wait starts, so that
+// a signal arriving right after RunUntilSignal is called can never race
+// past cleanups that haven't been registered yet. This is why
+// RunUntilSignal takes register instead of returning a bare TearFn: a
+// caller given the TearFn back would have to register its cleanups after
+// the call returned, which is exactly the window a signal could land in.
+//
+// This lets a program wire up graceful shutdown end-to-end:
+//
+//	shutdown := tears.RunUntilSignal(context.Background(), func(tear tears.TearFn) {
+//	    tears.TearHTTPServer(tear, srv)
+//	})
+//	if err := <-shutdown; err != nil {
+//	    log.Print(err)
+//	}
+func RunUntilSignal(ctx context.Context, register func(tear TearFn), sigs ...os.Signal) <-chan error {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	var cleaner Cleaner
+	register(cleaner.Tear)
+
+	done := make(chan error, 1)
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, sigs...)
+
+	go func() {
+		select {
+		case <-sigc:
+		case <-ctx.Done():
+		}
+		signal.Stop(sigc)
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), Timeout)
+		defer cancel()
+
+		done <- cleaner.Down(shutdownCtx)
+		close(done)
+	}()
+
+	return done
+}
+
+// TearHTTPServer registers srv.Shutdown with tear as a cleanup, so that
+// Down, or the shutdown triggered by RunUntilSignal, gracefully shuts the
+// server down. It accepts a TearFn, as returned by New or passed to the
+// register function of RunUntilSignal, rather than a Cleaner directly, mirroring the RegisterOnShutdown pattern
+// common in Go services.
+func TearHTTPServer(tear TearFn, srv *http.Server) *Tear {
+	return tear(srv.Shutdown)
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 Marius Wilms All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tears
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRunUntilSignalOnContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var called bool
+	shutdown := RunUntilSignal(ctx, func(tear TearFn) {
+		tear(func() error {
+			called = true
+			return nil
+		})
+	})
+
+	cancel()
+
+	select {
+	case err := <-shutdown:
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected shutdown to complete once ctx was canceled")
+	}
+	if !called {
+		t.Error("Expected the registered cleanup to run")
+	}
+}
+
+func TestRunUntilSignalOnSignal(t *testing.T) {
+	var called bool
+	shutdown := RunUntilSignal(context.Background(), func(tear TearFn) {
+		tear(func() error {
+			called = true
+			return nil
+		})
+	}, syscall.SIGUSR1)
+
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Signal(syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-shutdown:
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected shutdown to complete once the signal was received")
+	}
+	if !called {
+		t.Error("Expected the registered cleanup to run")
+	}
+}
+
+func TestTearHTTPServer(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Start()
+	defer srv.Close()
+
+	tear, down := New()
+	TearHTTPServer(tear, srv.Config)
+
+	if err := down(context.Background()); err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+}